@@ -14,6 +14,13 @@ All logging middleware will emit a final log statement. It is based on the error
 the gRPC status code, an error (if any) and it emit at a level controlled via `WithLevels`. You can control this behavior
 using `WithDecider`.
 
+The request/response payload loggers of each provider take a separate `PayloadDecider` (evaluated after the handler
+server-side, so it sees the call's error; for a client-side stream, once when the stream is opened and before any
+send is marshalled, with a nil error), so that noisy
+payload logging can be sampled independently of the final log line above - e.g. via `ErrorAndSampleDecider` or
+`CodeSamplingDecider`. The resulting decision is attached to the call as the `grpc.payload.sampled` field via
+`InjectFields`, so it shows up on the final log line too.
+
 This parent package
 
 This particular package is intended for use by other middleware, logging or otherwise. It contains interfaces that other
@@ -0,0 +1,105 @@
+// Copyright 2017 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package logging
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+func serviceAndMethod(fullMethodName string) (service, method string) {
+	fullMethodName = fullMethodName[1:] // strip leading slash
+	for j := len(fullMethodName) - 1; j >= 0; j-- {
+		if fullMethodName[j] == '/' {
+			return fullMethodName[:j], fullMethodName[j+1:]
+		}
+	}
+	return fullMethodName, ""
+}
+
+func report(ctx context.Context, logger Logger, o *options, kind, fullMethodName string, start time.Time, err error) {
+	if !o.shouldLog(ctx, fullMethodName, err) {
+		return
+	}
+	service, method := serviceAndMethod(fullMethodName)
+	code := statusCode(err)
+	fields := Fields{
+		"grpc.service", service,
+		"grpc.method", method,
+		"grpc.code", code.String(),
+		"grpc.time_ms", float64(time.Since(start).Nanoseconds()) / 1e6,
+	}
+	fields = fields.AppendUnique(o.fields(ctx))
+	fields = fields.AppendUnique(ExtractFields(ctx))
+
+	msg := "finished " + kind + " call"
+	if err != nil {
+		logger.Log(ctx, o.levelFunc(code), msg, append(fields, "grpc.error", err.Error())...)
+		return
+	}
+	logger.Log(ctx, o.levelFunc(code), msg, fields...)
+}
+
+// UnaryServerInterceptor returns a new unary server interceptor that emits a final log line, via logger, once the
+// handler returns.
+func UnaryServerInterceptor(logger Logger, opts ...Option) grpc.UnaryServerInterceptor {
+	o := evaluateOptions(opts)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = newLoggerContext(ctx)
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		report(ctx, logger, o, "unary", info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a new streaming server interceptor that emits a final log line, via logger, once
+// the handler returns.
+func StreamServerInterceptor(logger Logger, opts ...Option) grpc.StreamServerInterceptor {
+	o := evaluateOptions(opts)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		wrapped := &loggerContextServerStream{ServerStream: ss, ctx: newLoggerContext(ss.Context())}
+		start := time.Now()
+		err := handler(srv, wrapped)
+		report(wrapped.ctx, logger, o, "streaming", info.FullMethod, start, err)
+		return err
+	}
+}
+
+// loggerContextServerStream overrides Context() so that the fieldsHolder installed by StreamServerInterceptor is
+// visible to (and can be added to by) every interceptor further down the chain, including ones wrapping ss again.
+type loggerContextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *loggerContextServerStream) Context() context.Context { return s.ctx }
+
+// UnaryClientInterceptor returns a new unary client interceptor that emits a final log line, via logger, once the
+// invoker returns.
+func UnaryClientInterceptor(logger Logger, opts ...Option) grpc.UnaryClientInterceptor {
+	o := evaluateOptions(opts)
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx = newLoggerContext(ctx)
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		report(ctx, logger, o, "unary", method, start, err)
+		return err
+	}
+}
+
+// StreamClientInterceptor returns a new streaming client interceptor that emits a final log line, via logger, once
+// the call to open the stream returns. It does not wait for the stream to be closed.
+func StreamClientInterceptor(logger Logger, opts ...Option) grpc.StreamClientInterceptor {
+	o := evaluateOptions(opts)
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx = newLoggerContext(ctx)
+		start := time.Now()
+		clientStream, err := streamer(ctx, desc, cc, method, opts...)
+		report(ctx, logger, o, "streaming", method, start, err)
+		return clientStream, err
+	}
+}
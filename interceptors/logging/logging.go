@@ -0,0 +1,102 @@
+// Copyright 2017 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package logging
+
+import (
+	"context"
+	"sync"
+)
+
+// Level defines the severity of a log statement, as emitted by the generic interceptors in this package.
+type Level string
+
+const (
+	LevelDebug Level = "debug"
+	LevelInfo  Level = "info"
+	LevelWarn  Level = "warn"
+	LevelError Level = "error"
+)
+
+// Logger is the generic logging interface that implementations (providers/logrus, providers/zap, providers/kit,
+// providers/zerolog) adapt their concrete logger to, and which the generic interceptors in this package log through.
+type Logger interface {
+	// Log logs a message, alongside fields as alternating key/value pairs, at the given Level.
+	Log(ctx context.Context, level Level, msg string, fields ...any)
+}
+
+// LoggerFunc is a function adapter that implements Logger.
+type LoggerFunc func(ctx context.Context, level Level, msg string, fields ...any)
+
+func (f LoggerFunc) Log(ctx context.Context, level Level, msg string, fields ...any) {
+	f(ctx, level, msg, fields...)
+}
+
+// Fields represents logging fields as a slice of alternating key/value pairs, e.g. Fields{"grpc.service", "foo"}.
+type Fields []any
+
+// AppendUnique appends the given fields to f, skipping any key already present in f.
+func (f Fields) AppendUnique(new Fields) Fields {
+	existing := make(map[any]struct{}, len(f)/2)
+	for i := 0; i < len(f); i += 2 {
+		existing[f[i]] = struct{}{}
+	}
+	out := f
+	for i := 0; i+1 < len(new); i += 2 {
+		if _, ok := existing[new[i]]; ok {
+			continue
+		}
+		out = append(out, new[i], new[i+1])
+	}
+	return out
+}
+
+type ctxMarkerKey struct{}
+
+var ctxMarker = &ctxMarkerKey{}
+
+// fieldsHolder is stashed once per call, as a pointer, so that Fields added deep inside a handler (e.g. by a
+// payload interceptor running after this package's own interceptor has already called the handler) are visible to
+// the final log line. This holds only for interceptors nested inside this package's own: one placed outside it sees
+// no holder on its ctx, and anything it injects after the handler returns comes after the final line was emitted.
+type fieldsHolder struct {
+	mu     sync.Mutex
+	fields Fields
+}
+
+// newLoggerContext installs a fresh fieldsHolder on ctx if one isn't already present. The generic interceptors in
+// this package call it once, at the start of each call.
+func newLoggerContext(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(ctxMarker).(*fieldsHolder); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxMarker, &fieldsHolder{})
+}
+
+// ExtractFields returns the gRPC request-scoped Fields accumulated so far on ctx by the logging interceptors (and
+// anything InjectFields added), or an empty Fields if none were ever attached.
+func ExtractFields(ctx context.Context) Fields {
+	h, ok := ctx.Value(ctxMarker).(*fieldsHolder)
+	if !ok {
+		return Fields{}
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append(Fields{}, h.fields...)
+}
+
+// InjectFields merges fields into the Fields already carried by ctx, so that subsequent calls to ExtractFields
+// (including the final log line emitted by the interceptors in this package) observe them. It mutates the holder
+// installed by the enclosing logging interceptor in place, so it works regardless of where in the interceptor
+// chain it is called from; it returns ctx unchanged for convenient chaining at call sites that expect a context
+// back (e.g. `ctx = logging.InjectFields(ctx, ...)`).
+func InjectFields(ctx context.Context, fields Fields) context.Context {
+	h, ok := ctx.Value(ctxMarker).(*fieldsHolder)
+	if !ok {
+		return ctx
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fields = h.fields.AppendUnique(fields)
+	return ctx
+}
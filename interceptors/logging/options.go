@@ -0,0 +1,97 @@
+// Copyright 2017 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package logging
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Decider decides whether the generic interceptors in this package should emit their final log line for a given
+// call. ctx carries the Fields attached so far, fullMethodName has the form "/package.Service/Method", and err is
+// the error (if any) returned by the handler/invoker.
+type Decider func(ctx context.Context, fullMethodName string, err error) bool
+
+// CodeToLevel maps a gRPC status code to the Level its final log line should be emitted at.
+type CodeToLevel func(code codes.Code) Level
+
+// DefaultServerCodeToLevel is the default CodeToLevel: OK and the client-caused codes (NotFound, AlreadyExists,
+// InvalidArgument, Unauthenticated) are informational; cancellation, deadlines, PermissionDenied, ResourceExhausted,
+// FailedPrecondition, Aborted and OutOfRange are warnings; Unknown, Unimplemented, Internal, Unavailable, DataLoss
+// and any unrecognised code are errors.
+func DefaultServerCodeToLevel(code codes.Code) Level {
+	switch code {
+	case codes.OK, codes.NotFound, codes.AlreadyExists, codes.InvalidArgument, codes.Unauthenticated:
+		return LevelInfo
+	case codes.Canceled, codes.DeadlineExceeded, codes.PermissionDenied, codes.ResourceExhausted, codes.FailedPrecondition, codes.Aborted, codes.OutOfRange:
+		return LevelWarn
+	case codes.Unknown, codes.Unimplemented, codes.Internal, codes.Unavailable, codes.DataLoss:
+		return LevelError
+	default:
+		return LevelError
+	}
+}
+
+// DefaultClientCodeToLevel is a CodeToLevel for the calling side: Unknown, Internal, Unavailable, DataLoss and any
+// unrecognised code are warnings, and every other code (OK included) is logged at LevelDebug.
+func DefaultClientCodeToLevel(code codes.Code) Level {
+	switch code {
+	case codes.OK, codes.Canceled, codes.DeadlineExceeded, codes.NotFound, codes.AlreadyExists, codes.InvalidArgument,
+		codes.Unauthenticated, codes.PermissionDenied, codes.ResourceExhausted, codes.FailedPrecondition, codes.Aborted, codes.OutOfRange, codes.Unimplemented:
+		return LevelDebug
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss:
+		return LevelWarn
+	default:
+		return LevelWarn
+	}
+}
+
+type options struct {
+	levelFunc     CodeToLevel
+	shouldLog     Decider
+	fieldsFromCtx func(ctx context.Context) Fields
+}
+
+// Option configures the behavior of the generic interceptors in this package.
+type Option func(*options)
+
+func evaluateOptions(opts []Option) *options {
+	o := &options{
+		levelFunc: DefaultServerCodeToLevel,
+		shouldLog: func(ctx context.Context, fullMethodName string, err error) bool { return true },
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithDecider customizes the Decider used to decide whether the final log line of a call should be emitted.
+func WithDecider(f Decider) Option {
+	return func(o *options) { o.shouldLog = f }
+}
+
+// WithLevels customizes the CodeToLevel used to pick the Level of the final log line of a call.
+func WithLevels(f CodeToLevel) Option {
+	return func(o *options) { o.levelFunc = f }
+}
+
+// WithFieldsFromContext extracts additional Fields (e.g. a request id) out of ctx to attach to every log line of
+// a call.
+func WithFieldsFromContext(f func(ctx context.Context) Fields) Option {
+	return func(o *options) { o.fieldsFromCtx = f }
+}
+
+func (o *options) fields(ctx context.Context) Fields {
+	if o.fieldsFromCtx == nil {
+		return Fields{}
+	}
+	return o.fieldsFromCtx(ctx)
+}
+
+func statusCode(err error) codes.Code {
+	return status.Code(err)
+}
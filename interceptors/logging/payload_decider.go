@@ -0,0 +1,70 @@
+// Copyright 2017 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package logging
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+)
+
+// PayloadDecider decides whether the (typically much noisier) request/response payload logger of a given provider
+// should fire for a call. Server-side and for unary client calls it is evaluated after the handler/invoker returns,
+// so err is the call's outcome. For client-side streams it is evaluated once, when the stream is opened and before
+// any message is marshalled for sending; the outcome is not known then, so err is nil. Providers attach
+// the outcome via InjectFields as the "grpc.payload.sampled" field (true/false), so the final log line emitted by
+// the generic interceptors in this package records whether payloads were logged for that call.
+type PayloadDecider func(ctx context.Context, fullMethodName string, err error) bool
+
+// ErrorAndSampleDecider returns a PayloadDecider that always logs payloads for failed calls, and logs payloads for
+// successful calls with probability successFraction (0.0 logs none, 1.0 logs all). Each call draws its own,
+// independent roll via src (or the default global source if src is nil) - pass a fixed rand.Source in tests for a
+// reproducible sequence of per-call draws.
+func ErrorAndSampleDecider(successFraction float64, src ...rand.Source) PayloadDecider {
+	roll := sampleRoll(src)
+	return func(ctx context.Context, fullMethodName string, err error) bool {
+		if err != nil {
+			return true
+		}
+		return roll() < successFraction
+	}
+}
+
+// CodeSamplingDecider returns a PayloadDecider that logs payloads for a call with the probability configured for
+// its resulting codes.Code in fractions, defaulting to always logging codes absent from the map. As with
+// ErrorAndSampleDecider, every call draws its own independent roll via src (or the default global source).
+func CodeSamplingDecider(fractions map[codes.Code]float64, src ...rand.Source) PayloadDecider {
+	roll := sampleRoll(src)
+	return func(ctx context.Context, fullMethodName string, err error) bool {
+		fraction, ok := fractions[statusCode(err)]
+		if !ok {
+			return true
+		}
+		return roll() < fraction
+	}
+}
+
+// sampleRoll returns a func drawing an independent, uniformly distributed float64 in [0, 1) on every call, safe for
+// concurrent use. The default global source (rand.Float64) already is; src, when given, is wrapped in a rand.Rand
+// guarded by a mutex, since rand.Rand itself is not safe for concurrent use.
+func sampleRoll(src []rand.Source) func() float64 {
+	if len(src) > 0 && src[0] != nil {
+		r := rand.New(src[0])
+		var mu sync.Mutex
+		return func() float64 {
+			mu.Lock()
+			defer mu.Unlock()
+			return r.Float64()
+		}
+	}
+	return rand.Float64
+}
+
+// InjectPayloadSampled attaches the "grpc.payload.sampled" field to ctx's Fields (see InjectFields), recording
+// whether a PayloadDecider chose to log payloads for the call carried by ctx.
+func InjectPayloadSampled(ctx context.Context, sampled bool) context.Context {
+	return InjectFields(ctx, Fields{"grpc.payload.sampled", sampled})
+}
@@ -0,0 +1,97 @@
+// Copyright 2017 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package logging_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func unaryServerInfo(fullMethod string) *grpc.UnaryServerInfo {
+	return &grpc.UnaryServerInfo{FullMethod: fullMethod}
+}
+
+func TestErrorAndSampleDecider(t *testing.T) {
+	for _, tt := range []struct {
+		name            string
+		successFraction float64
+		src             rand.Source
+		err             error
+		want            bool
+	}{
+		{name: "errors always sampled even at 0%", successFraction: 0, src: rand.NewSource(1), err: status.Error(codes.Internal, "boom"), want: true},
+		{name: "0%% success fraction never samples successes", successFraction: 0, src: rand.NewSource(1), err: nil, want: false},
+		{name: "100%% success fraction always samples successes", successFraction: 1, src: rand.NewSource(1), err: nil, want: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			decider := logging.ErrorAndSampleDecider(tt.successFraction, tt.src)
+			require.Equal(t, tt.want, decider(context.Background(), "/mwitkow.testproto.TestService/Ping", tt.err))
+		})
+	}
+}
+
+func TestErrorAndSampleDecider_IndependentPerCall(t *testing.T) {
+	decider := logging.ErrorAndSampleDecider(0.5, rand.NewSource(42))
+	var sampled, skipped bool
+	for i := 0; i < 50; i++ {
+		if decider(context.Background(), "/mwitkow.testproto.TestService/Ping", nil) {
+			sampled = true
+		} else {
+			skipped = true
+		}
+	}
+	require.True(t, sampled, "a 50% success fraction must sample at least one of 50 calls")
+	require.True(t, skipped, "a 50% success fraction must skip at least one of 50 calls, not log them all")
+}
+
+func TestCodeSamplingDecider(t *testing.T) {
+	fractions := map[codes.Code]float64{
+		codes.NotFound: 0,
+		codes.Internal: 1,
+	}
+	decider := logging.CodeSamplingDecider(fractions, rand.NewSource(1))
+
+	require.False(t, decider(context.Background(), "/mwitkow.testproto.TestService/Ping", status.Error(codes.NotFound, "nope")))
+	require.True(t, decider(context.Background(), "/mwitkow.testproto.TestService/Ping", status.Error(codes.Internal, "boom")))
+	require.True(t, decider(context.Background(), "/mwitkow.testproto.TestService/Ping", nil), "codes absent from the fractions map must default to always sampling")
+}
+
+// fakeLogger records the fields of the final log line emitted by the generic interceptors.
+type fakeLogger struct {
+	fields logging.Fields
+}
+
+func (f *fakeLogger) Log(ctx context.Context, level logging.Level, msg string, fields ...any) {
+	f.fields = append(logging.Fields{}, fields...)
+}
+
+// TestInjectPayloadSampled verifies that a field attached deep inside a handler - as a payload interceptor running
+// after logging.UnaryServerInterceptor's own handler call would do - reaches the final log line it emits.
+func TestInjectPayloadSampled(t *testing.T) {
+	fake := &fakeLogger{}
+	interceptor := logging.UnaryServerInterceptor(fake)
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		logging.InjectPayloadSampled(ctx, true)
+		return "resp", nil
+	}
+	_, err := interceptor(context.Background(), "req", unaryServerInfo("/mwitkow.testproto.TestService/Ping"), handler)
+	require.NoError(t, err)
+
+	found := false
+	for i := 0; i+1 < len(fake.fields); i += 2 {
+		if fake.fields[i] == "grpc.payload.sampled" {
+			require.Equal(t, true, fake.fields[i+1])
+			found = true
+		}
+	}
+	require.True(t, found, "grpc.payload.sampled field must be attached to the final log line")
+}
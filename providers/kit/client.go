@@ -0,0 +1,22 @@
+// Copyright 2017 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package kit
+
+import (
+	"github.com/go-kit/log"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"google.golang.org/grpc"
+)
+
+// UnaryClientInterceptor returns a new unary client interceptor that logs the execution of gRPC calls using a
+// go-kit Logger.
+func UnaryClientInterceptor(logger log.Logger, opts ...logging.Option) grpc.UnaryClientInterceptor {
+	return logging.UnaryClientInterceptor(InterceptorLogger(logger), opts...)
+}
+
+// StreamClientInterceptor returns a new streaming client interceptor that logs the execution of gRPC calls using a
+// go-kit Logger.
+func StreamClientInterceptor(logger log.Logger, opts ...logging.Option) grpc.StreamClientInterceptor {
+	return logging.StreamClientInterceptor(InterceptorLogger(logger), opts...)
+}
@@ -0,0 +1,37 @@
+// Copyright 2017 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+// Package ctxkit extracts a go-kit Logger, enriched with the gRPC request-scoped logging.Fields, from a context.
+package ctxkit
+
+import (
+	"context"
+
+	"github.com/go-kit/log"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+)
+
+type ctxMarkerKey struct{}
+
+var ctxMarker = &ctxMarkerKey{}
+
+// ToContext stashes the given Logger as the fallback logger for Extract to build upon.
+func ToContext(ctx context.Context, logger log.Logger) context.Context {
+	return context.WithValue(ctx, ctxMarker, logger)
+}
+
+// Extract takes the Logger previously attached via ToContext (falling back to a no-op Logger), and returns a child
+// Logger with all gRPC request-scoped logging.Fields (populated by the logging interceptors) already attached.
+func Extract(ctx context.Context) log.Logger {
+	l, ok := ctx.Value(ctxMarker).(log.Logger)
+	if !ok || l == nil {
+		l = log.NewNopLogger()
+	}
+
+	fields := logging.ExtractFields(ctx)
+	kvs := make([]interface{}, len(fields))
+	for i, f := range fields {
+		kvs[i] = f
+	}
+	return log.With(l, kvs...)
+}
@@ -0,0 +1,36 @@
+// Copyright 2017 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+// Package kit adapts a go-kit Logger to the `logging.Logger` interface defined in the parent `logging` package,
+// so it can be used with the generic interceptors/logging unary and streaming interceptors.
+package kit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-kit/log"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+)
+
+// InterceptorLogger adapts go-kit's log.Logger to interceptor logging.Logger.
+func InterceptorLogger(l log.Logger) logging.Logger {
+	return logging.LoggerFunc(func(ctx context.Context, lvl logging.Level, msg string, fields ...any) {
+		kvs := append([]interface{}{"msg", msg}, fields...)
+
+		switch lvl {
+		case logging.LevelDebug:
+			kvs = append(kvs, "level", "debug")
+		case logging.LevelInfo:
+			kvs = append(kvs, "level", "info")
+		case logging.LevelWarn:
+			kvs = append(kvs, "level", "warn")
+		case logging.LevelError:
+			kvs = append(kvs, "level", "error")
+		default:
+			panic(fmt.Sprintf("unknown level %v", lvl))
+		}
+
+		_ = l.Log(kvs...)
+	})
+}
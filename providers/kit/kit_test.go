@@ -0,0 +1,87 @@
+// Copyright 2017 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package kit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/providers/kit"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLogger is a minimal go-kit log.Logger that just records the keyvals it was asked to log.
+type fakeLogger struct {
+	keyvals []interface{}
+}
+
+func (f *fakeLogger) Log(keyvals ...interface{}) error {
+	f.keyvals = append(f.keyvals, keyvals...)
+	return nil
+}
+
+func (f *fakeLogger) get(key string) (interface{}, bool) {
+	for i := 0; i+1 < len(f.keyvals); i += 2 {
+		if f.keyvals[i] == key {
+			return f.keyvals[i+1], true
+		}
+	}
+	return nil, false
+}
+
+func TestInterceptorLogger(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		level     logging.Level
+		code      string
+		wantLevel string
+	}{
+		{name: "ok call logs info", level: logging.LevelInfo, code: "OK", wantLevel: "info"},
+		{name: "erroring call logs error", level: logging.LevelError, code: "Internal", wantLevel: "error"},
+		{name: "cancelled call logs warn", level: logging.LevelWarn, code: "Canceled", wantLevel: "warn"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeLogger{}
+			logger := kit.InterceptorLogger(fake)
+
+			logger.Log(context.Background(), tt.level, "finished unary call", "grpc.service", "mwitkow.testproto.TestService", "grpc.method", "PingEmpty", "grpc.code", tt.code)
+
+			gotLevel, ok := fake.get("level")
+			require.True(t, ok, "level field must be present")
+			require.Equal(t, tt.wantLevel, gotLevel)
+
+			gotService, ok := fake.get("grpc.service")
+			require.True(t, ok, "grpc.service field must be present")
+			require.Equal(t, "mwitkow.testproto.TestService", gotService)
+
+			gotMethod, ok := fake.get("grpc.method")
+			require.True(t, ok, "grpc.method field must be present")
+			require.Equal(t, "PingEmpty", gotMethod)
+
+			gotCode, ok := fake.get("grpc.code")
+			require.True(t, ok, "grpc.code field must be present")
+			require.Equal(t, tt.code, gotCode)
+
+			gotMsg, ok := fake.get("msg")
+			require.True(t, ok, "msg field must be present")
+			require.Equal(t, "finished unary call", gotMsg)
+		})
+	}
+}
+
+func TestInterceptorLogger_StreamingCall(t *testing.T) {
+	fake := &fakeLogger{}
+	logger := kit.InterceptorLogger(fake)
+
+	logger.Log(context.Background(), logging.LevelError, "finished streaming call", "grpc.service", "mwitkow.testproto.TestService", "grpc.method", "PingList", "grpc.code", "Canceled")
+
+	gotCode, ok := fake.get("grpc.code")
+	require.True(t, ok)
+	require.Equal(t, "Canceled", gotCode)
+
+	gotLevel, ok := fake.get("level")
+	require.True(t, ok)
+	require.Equal(t, "error", gotLevel)
+}
@@ -0,0 +1,22 @@
+// Copyright 2017 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package kit
+
+import (
+	"github.com/go-kit/log"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"google.golang.org/grpc"
+)
+
+// UnaryServerInterceptor returns a new unary server interceptor that logs the execution of gRPC calls using a
+// go-kit Logger.
+func UnaryServerInterceptor(logger log.Logger, opts ...logging.Option) grpc.UnaryServerInterceptor {
+	return logging.UnaryServerInterceptor(InterceptorLogger(logger), opts...)
+}
+
+// StreamServerInterceptor returns a new streaming server interceptor that logs the execution of gRPC calls using a
+// go-kit Logger.
+func StreamServerInterceptor(logger log.Logger, opts ...logging.Option) grpc.StreamServerInterceptor {
+	return logging.StreamServerInterceptor(InterceptorLogger(logger), opts...)
+}
@@ -0,0 +1,20 @@
+// Copyright 2017 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package logrus
+
+import (
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+// UnaryClientInterceptor returns a new unary client interceptor that logs the final line of each call via logger.
+func UnaryClientInterceptor(logger *logrus.Logger, opts ...logging.Option) grpc.UnaryClientInterceptor {
+	return logging.UnaryClientInterceptor(InterceptorLogger(logger), opts...)
+}
+
+// StreamClientInterceptor returns a new streaming client interceptor that logs the final line of each call via logger.
+func StreamClientInterceptor(logger *logrus.Logger, opts ...logging.Option) grpc.StreamClientInterceptor {
+	return logging.StreamClientInterceptor(InterceptorLogger(logger), opts...)
+}
@@ -0,0 +1,42 @@
+// Copyright 2017 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+// Package logrus adapts a logrus.Logger to the `logging.Logger` interface defined in the parent `logging` package,
+// so it can be used with the generic interceptors/logging unary and streaming interceptors.
+package logrus
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"github.com/sirupsen/logrus"
+)
+
+// InterceptorLogger adapts a logrus.Logger to interceptor logging.Logger.
+func InterceptorLogger(l *logrus.Logger) logging.Logger {
+	return logging.LoggerFunc(func(ctx context.Context, lvl logging.Level, msg string, fields ...any) {
+		f := make(logrus.Fields, len(fields)/2)
+		for i := 0; i+1 < len(fields); i += 2 {
+			key, ok := fields[i].(string)
+			if !ok {
+				continue
+			}
+			f[key] = fields[i+1]
+		}
+		entry := l.WithContext(ctx).WithFields(f)
+
+		switch lvl {
+		case logging.LevelDebug:
+			entry.Debug(msg)
+		case logging.LevelInfo:
+			entry.Info(msg)
+		case logging.LevelWarn:
+			entry.Warn(msg)
+		case logging.LevelError:
+			entry.Error(msg)
+		default:
+			panic(fmt.Sprintf("unknown level %v", lvl))
+		}
+	})
+}
@@ -0,0 +1,63 @@
+// Copyright 2017 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package logrus_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	grpc_logrus "github.com/grpc-ecosystem/go-grpc-middleware/v2/providers/logrus"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestInterceptorLogger(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		level     logging.Level
+		code      string
+		wantLevel logrus.Level
+	}{
+		{name: "ok call logs info", level: logging.LevelInfo, code: "OK", wantLevel: logrus.InfoLevel},
+		{name: "erroring call logs error", level: logging.LevelError, code: "Internal", wantLevel: logrus.ErrorLevel},
+		{name: "cancelled call logs warn", level: logging.LevelWarn, code: "Canceled", wantLevel: logrus.WarnLevel},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			base, hook := test.NewNullLogger()
+			logger := grpc_logrus.InterceptorLogger(base)
+
+			logger.Log(context.Background(), tt.level, "finished unary call", "grpc.service", "mwitkow.testproto.TestService", "grpc.code", tt.code)
+
+			require.Len(t, hook.Entries, 1)
+			require.Equal(t, tt.wantLevel, hook.LastEntry().Level)
+			require.Equal(t, "finished unary call", hook.LastEntry().Message)
+			require.Equal(t, "mwitkow.testproto.TestService", hook.LastEntry().Data["grpc.service"])
+		})
+	}
+}
+
+func TestPayloadUnaryServerInterceptor_SamplerGatesLogging(t *testing.T) {
+	base, hook := test.NewNullLogger()
+	base.SetLevel(logrus.DebugLevel)
+
+	alwaysDecide := func(ctx context.Context, fullMethodName string, servingObject interface{}) bool { return true }
+	// No draw in [0, 1) is below a 0 success fraction, so ErrorAndSampleDecider(0) never samples a successful call;
+	// the fixed source only makes the draws reproducible.
+	sampler := logging.ErrorAndSampleDecider(0, rand.NewSource(1))
+
+	interceptor := grpc_logrus.PayloadUnaryServerInterceptor(base, logging.LevelDebug, alwaysDecide, sampler)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "resp", nil }
+	info := &grpc.UnaryServerInfo{FullMethod: "/mwitkow.testproto.TestService/PingEmpty"}
+
+	_, err := interceptor(context.Background(), "req", info, handler)
+	require.NoError(t, err)
+
+	for _, e := range hook.Entries {
+		require.NotEqual(t, "payload logged", e.Message, "a 0%% sample fraction must never log a successful call's payload")
+	}
+}
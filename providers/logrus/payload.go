@@ -0,0 +1,177 @@
+// Copyright 2017 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package logrus
+
+import (
+	"context"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// ServerPayloadLoggingDecider decides whether the server-side payload of a given RPC should be logged.
+type ServerPayloadLoggingDecider func(ctx context.Context, fullMethodName string, servingObject interface{}) bool
+
+// ClientPayloadLoggingDecider decides whether the client-side payload of a given RPC should be logged.
+type ClientPayloadLoggingDecider func(ctx context.Context, fullMethodName string) bool
+
+// PayloadUnaryServerInterceptor returns a new unary server interceptor that logs request and response protos at the
+// given level using logger, once decider allows it for the call and sampler (e.g. built via
+// logging.ErrorAndSampleDecider or logging.CodeSamplingDecider) allows it given the handler's outcome. Passing a
+// nil sampler logs every call that decider lets through. The "grpc.payload.sampled" field is always attached to
+// the call via logging.InjectFields, so the final log line records whether payloads were logged.
+func PayloadUnaryServerInterceptor(logger *logrus.Logger, level logging.Level, decider ServerPayloadLoggingDecider, sampler logging.PayloadDecider) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !decider(ctx, info.FullMethod, info.Server) {
+			return handler(ctx, req)
+		}
+		resp, err := handler(ctx, req)
+
+		sampled := allowPayload(sampler, ctx, info.FullMethod, err)
+		ctx = logging.InjectFields(ctx, logging.Fields{"grpc.payload.sampled", sampled})
+		if sampled {
+			logProtoMessage(ctx, logger, level, "grpc.request.content", req)
+			if err == nil {
+				logProtoMessage(ctx, logger, level, "grpc.response.content", resp)
+			}
+		}
+		return resp, err
+	}
+}
+
+// PayloadStreamServerInterceptor returns a new streaming server interceptor that logs request and response protos
+// at the given level using logger, gated by decider and sampler as in PayloadUnaryServerInterceptor. Messages are
+// buffered as they're sent/received and only actually logged, in order, once the handler returns, since sampler
+// needs the handler's outcome.
+func PayloadStreamServerInterceptor(logger *logrus.Logger, level logging.Level, decider ServerPayloadLoggingDecider, sampler logging.PayloadDecider) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !decider(ss.Context(), info.FullMethod, srv) {
+			return handler(srv, ss)
+		}
+		buffered := &bufferingServerStream{ServerStream: ss}
+		err := handler(srv, buffered)
+
+		ctx := ss.Context()
+		sampled := allowPayload(sampler, ctx, info.FullMethod, err)
+		ctx = logging.InjectFields(ctx, logging.Fields{"grpc.payload.sampled", sampled})
+		if sampled {
+			for _, m := range buffered.received {
+				logProtoMessage(ctx, logger, level, "grpc.request.content", m)
+			}
+			for _, m := range buffered.sent {
+				logProtoMessage(ctx, logger, level, "grpc.response.content", m)
+			}
+		}
+		return err
+	}
+}
+
+// PayloadUnaryClientInterceptor returns a new unary client interceptor that logs request and response protos at the
+// given level using logger, gated by decider and sampler as in PayloadUnaryServerInterceptor.
+func PayloadUnaryClientInterceptor(logger *logrus.Logger, level logging.Level, decider ClientPayloadLoggingDecider, sampler logging.PayloadDecider) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !decider(ctx, method) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		sampled := allowPayload(sampler, ctx, method, err)
+		ctx = logging.InjectFields(ctx, logging.Fields{"grpc.payload.sampled", sampled})
+		if sampled {
+			logProtoMessage(ctx, logger, level, "grpc.request.content", req)
+			if err == nil {
+				logProtoMessage(ctx, logger, level, "grpc.response.content", reply)
+			}
+		}
+		return err
+	}
+}
+
+// PayloadStreamClientInterceptor returns a new streaming client interceptor that logs request and response protos
+// at the given level using logger, gated by decider as before and, additionally, by sampler - evaluated once,
+// right after the stream is opened and before any message is marshalled onto the wire.
+func PayloadStreamClientInterceptor(logger *logrus.Logger, level logging.Level, decider ClientPayloadLoggingDecider, sampler logging.PayloadDecider) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		clientStream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil || !decider(ctx, method) {
+			return clientStream, err
+		}
+		sampled := allowPayload(sampler, ctx, method, nil)
+		ctx = logging.InjectFields(ctx, logging.Fields{"grpc.payload.sampled", sampled})
+		if !sampled {
+			return clientStream, nil
+		}
+		return &loggingClientStream{ClientStream: clientStream, logger: logger, level: level}, nil
+	}
+}
+
+// allowPayload reports whether sampler (if any) allows payload logging for the call; a nil sampler always allows
+// it, preserving the pre-sampling behavior of these interceptors.
+func allowPayload(sampler logging.PayloadDecider, ctx context.Context, fullMethodName string, err error) bool {
+	if sampler == nil {
+		return true
+	}
+	return sampler(ctx, fullMethodName, err)
+}
+
+// bufferingServerStream records every message sent/received on the underlying stream so PayloadStreamServerInterceptor
+// can decide, after the handler returns, whether to actually log them.
+type bufferingServerStream struct {
+	grpc.ServerStream
+	sent     []interface{}
+	received []interface{}
+}
+
+func (s *bufferingServerStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.sent = append(s.sent, m)
+	}
+	return err
+}
+
+func (s *bufferingServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.received = append(s.received, m)
+	}
+	return err
+}
+
+type loggingClientStream struct {
+	grpc.ClientStream
+	logger *logrus.Logger
+	level  logging.Level
+}
+
+func (s *loggingClientStream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		logProtoMessage(s.Context(), s.logger, s.level, "grpc.request.content", m)
+	}
+	return err
+}
+
+func (s *loggingClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil {
+		logProtoMessage(s.Context(), s.logger, s.level, "grpc.response.content", m)
+	}
+	return err
+}
+
+func logProtoMessage(ctx context.Context, logger *logrus.Logger, level logging.Level, field string, m interface{}) {
+	p, ok := m.(proto.Message)
+	if !ok {
+		return
+	}
+	content, err := protojson.Marshal(p)
+	if err != nil {
+		content = []byte(err.Error())
+	}
+	InterceptorLogger(logger).Log(ctx, level, "payload logged", field, string(content))
+}
@@ -0,0 +1,223 @@
+// Copyright 2016 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package metrics
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+)
+
+const (
+	typeUnary        = "unary"
+	typeClientStream = "client_stream"
+	typeServerStream = "server_stream"
+	typeBidiStream   = "bidi_stream"
+)
+
+// ClientMetrics represents a collection of Prometheus metrics that must be registered on a Prometheus registry for
+// a gRPC client.
+type ClientMetrics struct {
+	clientStartedCounter    *prometheus.CounterVec
+	clientHandledCounter    *prometheus.CounterVec
+	clientStreamMsgReceived *prometheus.CounterVec
+	clientStreamMsgSent     *prometheus.CounterVec
+
+	clientHandledHistogramEnabled bool
+	clientHandledHistogramOpts    prometheus.HistogramOpts
+	clientHandledHistogram        *prometheus.HistogramVec
+
+	payloadSizeHistogramEnabled bool
+	msgReceivedBytesHistogramOpts prometheus.HistogramOpts
+	msgSentBytesHistogramOpts     prometheus.HistogramOpts
+	clientMsgReceivedBytesHistogram *prometheus.HistogramVec
+	clientMsgSentBytesHistogram     *prometheus.HistogramVec
+
+	reg             prometheus.Registerer
+	exemplarFromCtx ExemplarFromContext
+}
+
+// NewClientMetrics returns a ClientMetrics object, pre-registered with the given Prometheus registerer.
+func NewClientMetrics(reg prometheus.Registerer, opts ...Option) *ClientMetrics {
+	o := evaluateOpts(opts)
+	if o.subsystem != "" {
+		o.constLabels["grpc_client_type"] = o.subsystem
+	}
+	m := &ClientMetrics{
+		reg:             reg,
+		exemplarFromCtx: o.exemplarFromCtx,
+		clientStartedCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "grpc_client_started_total",
+			Help:        "Total number of RPCs started on the client.",
+			ConstLabels: o.constLabels,
+		}, []string{"grpc_type", "grpc_service", "grpc_method"}),
+		clientHandledCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "grpc_client_handled_total",
+			Help:        "Total number of RPCs completed by the client, regardless of success or failure.",
+			ConstLabels: o.constLabels,
+		}, []string{"grpc_type", "grpc_service", "grpc_method", "grpc_code"}),
+		clientStreamMsgReceived: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "grpc_client_msg_received_total",
+			Help:        "Total number of RPC stream messages received by the client.",
+			ConstLabels: o.constLabels,
+		}, []string{"grpc_type", "grpc_service", "grpc_method"}),
+		clientStreamMsgSent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "grpc_client_msg_sent_total",
+			Help:        "Total number of gRPC stream messages sent by the client.",
+			ConstLabels: o.constLabels,
+		}, []string{"grpc_type", "grpc_service", "grpc_method"}),
+		clientHandledHistogramOpts: prometheus.HistogramOpts{
+			Name:        "grpc_client_handling_seconds",
+			Help:        "Histogram of response latency (seconds) of the gRPC until it is finished by the application.",
+			Buckets:     prometheus.DefBuckets,
+			ConstLabels: o.constLabels,
+		},
+		msgReceivedBytesHistogramOpts: prometheus.HistogramOpts{
+			Name:        "grpc_client_msg_received_bytes",
+			Help:        "Histogram of the total wire size, in bytes, of all messages received by the client in one RPC.",
+			Buckets:     prometheus.ExponentialBuckets(64, 4, 10),
+			ConstLabels: o.constLabels,
+		},
+		msgSentBytesHistogramOpts: prometheus.HistogramOpts{
+			Name:        "grpc_client_msg_sent_bytes",
+			Help:        "Histogram of the total wire size, in bytes, of all messages sent by the client in one RPC.",
+			Buckets:     prometheus.ExponentialBuckets(64, 4, 10),
+			ConstLabels: o.constLabels,
+		},
+	}
+
+	m.clientStartedCounter = registerOrReuse(reg, m.clientStartedCounter).(*prometheus.CounterVec)
+	m.clientHandledCounter = registerOrReuse(reg, m.clientHandledCounter).(*prometheus.CounterVec)
+	m.clientStreamMsgReceived = registerOrReuse(reg, m.clientStreamMsgReceived).(*prometheus.CounterVec)
+	m.clientStreamMsgSent = registerOrReuse(reg, m.clientStreamMsgSent).(*prometheus.CounterVec)
+	return m
+}
+
+// EnableClientHandlingTimeHistogram turns on recording of handling time of RPCs. Histogram metrics can be very
+// expensive for Prometheus to retain and query, so it is disabled by default.
+func (m *ClientMetrics) EnableClientHandlingTimeHistogram(opts ...HistogramOption) {
+	for _, o := range opts {
+		o(&m.clientHandledHistogramOpts)
+	}
+	if !m.clientHandledHistogramEnabled {
+		m.clientHandledHistogram = registerOrReuse(m.reg, prometheus.NewHistogramVec(
+			m.clientHandledHistogramOpts,
+			[]string{"grpc_type", "grpc_service", "grpc_method"},
+		)).(*prometheus.HistogramVec)
+	}
+	m.clientHandledHistogramEnabled = true
+}
+
+// EnablePayloadSizeHistogram turns on recording of the wire size of inbound and outbound message payloads, as
+// observed by a PayloadStatsHandler registered on the same ClientConn: one sample per RPC and direction, holding the
+// summed size of all its messages. Like the handling-time histogram, this is
+// disabled by default because histograms are expensive for Prometheus to retain and query.
+func (m *ClientMetrics) EnablePayloadSizeHistogram(opts ...HistogramOption) {
+	for _, o := range opts {
+		o(&m.msgReceivedBytesHistogramOpts)
+		o(&m.msgSentBytesHistogramOpts)
+	}
+	if !m.payloadSizeHistogramEnabled {
+		m.clientMsgReceivedBytesHistogram = registerOrReuse(m.reg, prometheus.NewHistogramVec(
+			m.msgReceivedBytesHistogramOpts,
+			[]string{"grpc_type", "grpc_service", "grpc_method"},
+		)).(*prometheus.HistogramVec)
+		m.clientMsgSentBytesHistogram = registerOrReuse(m.reg, prometheus.NewHistogramVec(
+			m.msgSentBytesHistogramOpts,
+			[]string{"grpc_type", "grpc_service", "grpc_method"},
+		)).(*prometheus.HistogramVec)
+	}
+	m.payloadSizeHistogramEnabled = true
+}
+
+// UnaryClientInterceptor is a grpc.UnaryClientInterceptor that provides Prometheus monitoring for unary RPCs.
+func (m *ClientMetrics) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		service, methodName := splitMethodName(method)
+		incCounter(ctx, m.exemplarFromCtx, m.clientStartedCounter.WithLabelValues(typeUnary, service, methodName))
+
+		start := time.Now()
+		err := invoker(withRPCType(ctx, typeUnary), method, req, reply, cc, opts...)
+		m.reportHandled(ctx, typeUnary, service, methodName, start, err)
+		return err
+	}
+}
+
+// StreamClientInterceptor is a grpc.StreamClientInterceptor that provides Prometheus monitoring for streaming RPCs.
+func (m *ClientMetrics) StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		service, methodName := splitMethodName(method)
+		rpcType := clientStreamType(desc)
+		incCounter(ctx, m.exemplarFromCtx, m.clientStartedCounter.WithLabelValues(rpcType, service, methodName))
+
+		start := time.Now()
+		clientStream, err := streamer(withRPCType(ctx, rpcType), desc, cc, method, opts...)
+		if err != nil {
+			m.reportHandled(ctx, rpcType, service, methodName, start, err)
+			return clientStream, err
+		}
+		return &monitoredClientStream{ClientStream: clientStream, metrics: m, rpcType: rpcType, service: service, method: methodName, start: start}, nil
+	}
+}
+
+func (m *ClientMetrics) reportHandled(ctx context.Context, rpcType, service, method string, start time.Time, err error) {
+	st, _ := statusFromError(err)
+	incCounter(ctx, m.exemplarFromCtx, m.clientHandledCounter.WithLabelValues(rpcType, service, method, st.Code().String()))
+	if m.clientHandledHistogramEnabled {
+		observeHistogram(ctx, m.exemplarFromCtx, m.clientHandledHistogram.WithLabelValues(rpcType, service, method), time.Since(start).Seconds())
+	}
+}
+
+type monitoredClientStream struct {
+	grpc.ClientStream
+	metrics *ClientMetrics
+	rpcType string
+	service string
+	method  string
+	start   time.Time
+}
+
+func (s *monitoredClientStream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		s.metrics.clientStreamMsgSent.WithLabelValues(s.rpcType, s.service, s.method).Inc()
+	}
+	return err
+}
+
+func (s *monitoredClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil {
+		s.metrics.clientStreamMsgReceived.WithLabelValues(s.rpcType, s.service, s.method).Inc()
+	} else if err == io.EOF {
+		s.metrics.reportHandled(s.Context(), s.rpcType, s.service, s.method, s.start, nil)
+	} else {
+		s.metrics.reportHandled(s.Context(), s.rpcType, s.service, s.method, s.start, err)
+	}
+	return err
+}
+
+func clientStreamType(desc *grpc.StreamDesc) string {
+	if desc.ClientStreams && desc.ServerStreams {
+		return typeBidiStream
+	} else if desc.ClientStreams {
+		return typeClientStream
+	}
+	return typeServerStream
+}
+
+// UnaryClientInterceptor is a convenience function that builds a ClientMetrics registered with reg and returns its
+// UnaryClientInterceptor.
+func UnaryClientInterceptor(reg prometheus.Registerer, opts ...Option) grpc.UnaryClientInterceptor {
+	return NewClientMetrics(reg, opts...).UnaryClientInterceptor()
+}
+
+// StreamClientInterceptor is a convenience function that builds a ClientMetrics registered with reg and returns its
+// StreamClientInterceptor.
+func StreamClientInterceptor(reg prometheus.Registerer, opts ...Option) grpc.StreamClientInterceptor {
+	return NewClientMetrics(reg, opts...).StreamClientInterceptor()
+}
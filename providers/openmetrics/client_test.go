@@ -9,6 +9,7 @@ import (
 
 	pb_testproto "github.com/grpc-ecosystem/go-grpc-middleware/providers/openmetrics/v2/testproto/v1"
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/grpc"
@@ -39,6 +40,7 @@ func (s *ClientInterceptorTestSuite) SetupSuite() {
 	var err error
 
 	DefaultClientMetrics.EnableClientHandlingTimeHistogram()
+	DefaultClientMetrics.EnablePayloadSizeHistogram()
 
 	s.serverListener, err = net.Listen("tcp", "127.0.0.1:0")
 	require.NoError(s.T(), err, "must be able to allocate a port for serverListener")
@@ -57,6 +59,7 @@ func (s *ClientInterceptorTestSuite) SetupSuite() {
 		grpc.WithBlock(),
 		grpc.WithUnaryInterceptor(UnaryClientInterceptor(prometheus.DefaultRegisterer)),
 		grpc.WithStreamInterceptor(StreamClientInterceptor(prometheus.DefaultRegisterer)),
+		grpc.WithStatsHandler(NewPayloadStatsHandler(DefaultClientMetrics, nil)),
 		grpc.WithTimeout(2*time.Second))
 	require.NoError(s.T(), err, "must not error on client Dial")
 	s.testClient = pb_testproto.NewTestServiceClient(s.clientConn)
@@ -72,6 +75,8 @@ func (s *ClientInterceptorTestSuite) SetupTest() {
 	DefaultClientMetrics.clientHandledHistogram.Reset()
 	DefaultClientMetrics.clientStreamMsgReceived.Reset()
 	DefaultClientMetrics.clientStreamMsgSent.Reset()
+	DefaultClientMetrics.clientMsgReceivedBytesHistogram.Reset()
+	DefaultClientMetrics.clientMsgSentBytesHistogram.Reset()
 }
 
 func (s *ClientInterceptorTestSuite) TearDownSuite() {
@@ -145,4 +150,77 @@ func (s *ClientInterceptorTestSuite) TestStreamingIncrementsMetrics() {
 	requireValue(s.T(), 2, DefaultClientMetrics.clientStartedCounter.WithLabelValues("server_stream", "mwitkow.testproto.TestService", "PingList"))
 	requireValue(s.T(), 1, DefaultClientMetrics.clientHandledCounter.WithLabelValues("server_stream", "mwitkow.testproto.TestService", "PingList", "FailedPrecondition"))
 	requireValueHistCount(s.T(), 2, DefaultClientMetrics.clientHandledHistogram.WithLabelValues("server_stream", "mwitkow.testproto.TestService", "PingList"))
+}
+
+func (s *ClientInterceptorTestSuite) TestStreamingIncrementsPayloadSizeHistograms() {
+	ss, _ := s.testClient.PingList(s.ctx, &pb_testproto.PingListRequest{}) // should return with code=OK
+	for {
+		_, err := ss.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(s.T(), err, "reading pingList shouldn't fail")
+	}
+
+	// The RPC's stats.End event (which is when we observe the final byte totals) fires asynchronously with
+	// respect to Recv returning EOF, so poll briefly rather than asserting immediately.
+	require.Eventually(s.T(), func() bool {
+		return histCount(DefaultClientMetrics.clientMsgSentBytesHistogram.WithLabelValues("server_stream", "mwitkow.testproto.TestService", "PingList")) == 1
+	}, time.Second, 10*time.Millisecond, "client msg sent bytes histogram must observe one sample")
+
+	requireValueHistCount(s.T(), 1, DefaultClientMetrics.clientMsgSentBytesHistogram.WithLabelValues("server_stream", "mwitkow.testproto.TestService", "PingList"))
+	requireValueHistCount(s.T(), 1, DefaultClientMetrics.clientMsgReceivedBytesHistogram.WithLabelValues("server_stream", "mwitkow.testproto.TestService", "PingList"))
+}
+
+func TestClientMetrics_WithSubsystemAndExemplar(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	exemplar := prometheus.Labels{"trace_id": "deadbeef"}
+	m := NewClientMetrics(reg,
+		WithSubsystem("internal"),
+		WithExemplarFromContext(func(ctx context.Context) prometheus.Labels { return exemplar }))
+	m.EnableClientHandlingTimeHistogram()
+
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return nil
+	}
+	err := m.UnaryClientInterceptor()(context.Background(), "/mwitkow.testproto.TestService/PingEmpty", nil, nil, nil, invoker)
+	require.NoError(t, err)
+
+	started := m.clientStartedCounter.WithLabelValues("unary", "mwitkow.testproto.TestService", "PingEmpty")
+	var startedMetric dto.Metric
+	require.NoError(t, started.(prometheus.Metric).Write(&startedMetric))
+
+	var gotSubsystem bool
+	for _, l := range startedMetric.GetLabel() {
+		if l.GetName() == "grpc_client_type" && l.GetValue() == "internal" {
+			gotSubsystem = true
+		}
+	}
+	require.True(t, gotSubsystem, "grpc_client_type const label must be set by WithSubsystem")
+	require.NotNil(t, startedMetric.GetCounter().GetExemplar(), "counter sample must carry an exemplar")
+	require.Equal(t, "deadbeef", exemplarValue(startedMetric.GetCounter().GetExemplar(), "trace_id"))
+
+	handled := m.clientHandledHistogram.WithLabelValues("unary", "mwitkow.testproto.TestService", "PingEmpty")
+	var handledMetric dto.Metric
+	require.NoError(t, handled.(prometheus.Metric).Write(&handledMetric))
+	require.EqualValues(t, 1, handledMetric.GetHistogram().GetSampleCount())
+}
+
+func exemplarValue(e *dto.Exemplar, key string) string {
+	for _, l := range e.GetLabel() {
+		if l.GetName() == key {
+			return l.GetValue()
+		}
+	}
+	return ""
+}
+
+func histCount(o prometheus.Observer) int {
+	var m dto.Metric
+	if c, ok := o.(prometheus.Metric); ok {
+		if err := c.Write(&m); err == nil {
+			return int(m.GetHistogram().GetSampleCount())
+		}
+	}
+	return 0
 }
\ No newline at end of file
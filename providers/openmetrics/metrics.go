@@ -0,0 +1,102 @@
+// Copyright 2016 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package metrics
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ExemplarFromContext returns labels (typically {trace_id, span_id} pulled from an OpenTelemetry span in ctx) to
+// attach as a Prometheus exemplar to the sample being recorded for the current RPC.
+type ExemplarFromContext func(ctx context.Context) prometheus.Labels
+
+// options holds the common configuration shared by ClientMetrics and ServerMetrics.
+type options struct {
+	constLabels     prometheus.Labels
+	subsystem       string
+	exemplarFromCtx ExemplarFromContext
+}
+
+// Option configures the construction of a ClientMetrics or ServerMetrics instance.
+type Option func(*options)
+
+func evaluateOpts(opts []Option) *options {
+	o := &options{constLabels: prometheus.Labels{}}
+	for _, f := range opts {
+		f(o)
+	}
+	return o
+}
+
+// WithConstLabels attaches the given constant labels to every collector created by NewClientMetrics or
+// NewServerMetrics. This is useful when a single process registers more than one set of gRPC metrics
+// (e.g. for two distinct gRPC servers) against the same Prometheus registry.
+func WithConstLabels(labels prometheus.Labels) Option {
+	return func(o *options) {
+		for k, v := range labels {
+			o.constLabels[k] = v
+		}
+	}
+}
+
+// WithSubsystem is a convenience over WithConstLabels that tags every collector with a `grpc_server_type` const
+// label (or its client-side equivalent), so that two gRPC servers/clients sharing one process and one Prometheus
+// registry (e.g. an internal RPC server and an external API server) don't collide on label sets.
+func WithSubsystem(name string) Option {
+	return func(o *options) {
+		o.subsystem = name
+	}
+}
+
+// WithExemplarFromContext enables Prometheus exemplar support: f is called for every sample of the started and
+// handled counters and of the handling-time histogram, and if it returns a non-nil set of labels, the sample is
+// recorded via the collector's ObserveWithExemplar/AddWithExemplar method instead of a plain Observe/Add. The stream
+// message counters and the payload-size histograms are recorded without exemplars.
+func WithExemplarFromContext(f ExemplarFromContext) Option {
+	return func(o *options) {
+		o.exemplarFromCtx = f
+	}
+}
+
+// incCounter increments c by one, attaching an exemplar derived from ctx via exemplarFromCtx if one is configured
+// and the underlying collector supports it.
+func incCounter(ctx context.Context, exemplarFromCtx ExemplarFromContext, c prometheus.Counter) {
+	if exemplarFromCtx != nil {
+		if labels := exemplarFromCtx(ctx); labels != nil {
+			if adder, ok := c.(prometheus.ExemplarAdder); ok {
+				adder.AddWithExemplar(1, labels)
+				return
+			}
+		}
+	}
+	c.Inc()
+}
+
+// observeHistogram behaves like incCounter, but for a histogram Observe call.
+func observeHistogram(ctx context.Context, exemplarFromCtx ExemplarFromContext, o prometheus.Observer, v float64) {
+	if exemplarFromCtx != nil {
+		if labels := exemplarFromCtx(ctx); labels != nil {
+			if adder, ok := o.(prometheus.ExemplarObserver); ok {
+				adder.ObserveWithExemplar(v, labels)
+				return
+			}
+		}
+	}
+	o.Observe(v)
+}
+
+// registerOrReuse registers c with reg, returning the already-registered collector of the same type if an
+// equivalent one was registered before. This lets multiple ClientMetrics/ServerMetrics instances share a
+// registry without panicking on a duplicate registration.
+func registerOrReuse(reg prometheus.Registerer, c prometheus.Collector) prometheus.Collector {
+	if err := reg.Register(c); err != nil {
+		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			return are.ExistingCollector
+		}
+		panic(err)
+	}
+	return c
+}
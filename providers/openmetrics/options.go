@@ -0,0 +1,18 @@
+// Copyright 2016 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// HistogramOption customizes the prometheus.HistogramOpts used to build a handling-time or payload-size histogram.
+type HistogramOption func(*prometheus.HistogramOpts)
+
+// WithHistogramBuckets allows customizing the bucket boundaries of a histogram enabled via
+// EnableClientHandlingTimeHistogram, EnableServerHandlingTimeHistogram, EnablePayloadSizeHistogram or their
+// server-side counterparts.
+func WithHistogramBuckets(buckets []float64) HistogramOption {
+	return func(o *prometheus.HistogramOpts) {
+		o.Buckets = buckets
+	}
+}
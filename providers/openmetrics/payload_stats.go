@@ -0,0 +1,177 @@
+// Copyright 2016 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package metrics
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc/stats"
+)
+
+// ContextTagger lets a caller fold the final inbound/outbound payload byte counts of a completed RPC back into its
+// context (typically via `logging.InjectFields` from the parent logging package), so that a logging middleware
+// further down the interceptor chain can pick them up on its final log line. Keeping this as a caller-supplied
+// callback, rather than importing the logging package directly, avoids a hard dependency between the two modules.
+type ContextTagger func(ctx context.Context, fields map[string]string) context.Context
+
+const (
+	fieldRequestPayloadBytes  = "grpc.request.payload_bytes"
+	fieldResponsePayloadBytes = "grpc.response.payload_bytes"
+)
+
+type payloadCounter struct {
+	service, method string
+	inBytes         int64
+	outBytes        int64
+
+	// rpcType is set in place by tagServerRPCType once the server interceptor chain knows it (unary vs. the various
+	// streaming flavors), and read back by ServerPayloadStatsHandler.observeAndTag. It can't be threaded through ctx
+	// values the way the client side does, because stats.Handler's callbacks run against the context lineage rooted
+	// at TagRPC, which predates (and isn't affected by) whatever the server interceptors do with their own ctx.
+	mu      sync.Mutex
+	rpcType string
+}
+
+type payloadCounterCtxMarker struct{}
+
+var payloadCounterCtxKey = &payloadCounterCtxMarker{}
+
+func payloadCounterFromContext(ctx context.Context) *payloadCounter {
+	c, _ := ctx.Value(payloadCounterCtxKey).(*payloadCounter)
+	return c
+}
+
+// tagServerRPCType records rpcType on the payloadCounter installed on ctx by ServerPayloadStatsHandler.TagRPC, if
+// any. The server interceptors call this as soon as they know the call's streaming flavor, so that
+// ServerPayloadStatsHandler.observeAndTag labels its histograms correctly even without a ContextTagger in play. It
+// is a no-op if no ServerPayloadStatsHandler is registered on the same grpc.Server.
+func tagServerRPCType(ctx context.Context, rpcType string) {
+	c := payloadCounterFromContext(ctx)
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	c.rpcType = rpcType
+	c.mu.Unlock()
+}
+
+// rpcTypeOr returns the rpcType tagged on c via tagServerRPCType, or def if none was tagged (e.g. no server
+// interceptor chain is installed alongside the stats handler).
+func (c *payloadCounter) rpcTypeOr(def string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.rpcType == "" {
+		return def
+	}
+	return c.rpcType
+}
+
+// PayloadStatsHandler is a grpc/stats.Handler that sums the wire size of inbound and outbound message payloads per
+// RPC and, when the RPC ends, records each total as one sample on a ClientMetrics' payload-size histograms, and
+// optionally attaches the totals to the context via a ContextTagger.
+type PayloadStatsHandler struct {
+	metrics       *ClientMetrics
+	contextTagger ContextTagger
+}
+
+// NewPayloadStatsHandler returns a PayloadStatsHandler recording payload sizes on m. Pass nil for tagger if the
+// per-RPC byte counts don't need to be surfaced to a logging middleware.
+func NewPayloadStatsHandler(m *ClientMetrics, tagger ContextTagger) *PayloadStatsHandler {
+	return &PayloadStatsHandler{metrics: m, contextTagger: tagger}
+}
+
+func (h *PayloadStatsHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	service, method := splitMethodName(info.FullMethodName)
+	return context.WithValue(ctx, payloadCounterCtxKey, &payloadCounter{service: service, method: method})
+}
+
+func (h *PayloadStatsHandler) HandleRPC(ctx context.Context, rs stats.RPCStats) {
+	counter := payloadCounterFromContext(ctx)
+	if counter == nil {
+		return
+	}
+	switch s := rs.(type) {
+	case *stats.OutPayload:
+		atomic.AddInt64(&counter.outBytes, int64(s.WireLength))
+	case *stats.InPayload:
+		atomic.AddInt64(&counter.inBytes, int64(s.WireLength))
+	case *stats.End:
+		h.observeAndTag(ctx, counter)
+	}
+}
+
+func (h *PayloadStatsHandler) observeAndTag(ctx context.Context, counter *payloadCounter) {
+	in, out := atomic.LoadInt64(&counter.inBytes), atomic.LoadInt64(&counter.outBytes)
+	rpcType := rpcTypeFromContext(ctx)
+
+	if h.metrics.payloadSizeHistogramEnabled {
+		h.metrics.clientMsgReceivedBytesHistogram.WithLabelValues(rpcType, counter.service, counter.method).Observe(float64(in))
+		h.metrics.clientMsgSentBytesHistogram.WithLabelValues(rpcType, counter.service, counter.method).Observe(float64(out))
+	}
+	if h.contextTagger != nil {
+		h.contextTagger(ctx, payloadBytesFields(in, out))
+	}
+}
+
+func (h *PayloadStatsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context { return ctx }
+func (h *PayloadStatsHandler) HandleConn(ctx context.Context, _ stats.ConnStats)                 {}
+
+// ServerPayloadStatsHandler is the server-side analog of PayloadStatsHandler.
+type ServerPayloadStatsHandler struct {
+	metrics       *ServerMetrics
+	contextTagger ContextTagger
+}
+
+// NewServerPayloadStatsHandler returns a ServerPayloadStatsHandler recording payload sizes on m. Pass nil for
+// tagger if the per-RPC byte counts don't need to be surfaced to a logging middleware.
+func NewServerPayloadStatsHandler(m *ServerMetrics, tagger ContextTagger) *ServerPayloadStatsHandler {
+	return &ServerPayloadStatsHandler{metrics: m, contextTagger: tagger}
+}
+
+func (h *ServerPayloadStatsHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	service, method := splitMethodName(info.FullMethodName)
+	return context.WithValue(ctx, payloadCounterCtxKey, &payloadCounter{service: service, method: method})
+}
+
+func (h *ServerPayloadStatsHandler) HandleRPC(ctx context.Context, rs stats.RPCStats) {
+	counter := payloadCounterFromContext(ctx)
+	if counter == nil {
+		return
+	}
+	switch s := rs.(type) {
+	case *stats.InPayload:
+		atomic.AddInt64(&counter.inBytes, int64(s.WireLength))
+	case *stats.OutPayload:
+		atomic.AddInt64(&counter.outBytes, int64(s.WireLength))
+	case *stats.End:
+		h.observeAndTag(ctx, counter)
+	}
+}
+
+func (h *ServerPayloadStatsHandler) observeAndTag(ctx context.Context, counter *payloadCounter) {
+	in, out := atomic.LoadInt64(&counter.inBytes), atomic.LoadInt64(&counter.outBytes)
+	rpcType := counter.rpcTypeOr(typeUnary)
+
+	if h.metrics.payloadSizeHistogramEnabled {
+		h.metrics.serverMsgReceivedBytesHistogram.WithLabelValues(rpcType, counter.service, counter.method).Observe(float64(in))
+		h.metrics.serverMsgSentBytesHistogram.WithLabelValues(rpcType, counter.service, counter.method).Observe(float64(out))
+	}
+	if h.contextTagger != nil {
+		h.contextTagger(ctx, payloadBytesFields(in, out))
+	}
+}
+
+func (h *ServerPayloadStatsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+func (h *ServerPayloadStatsHandler) HandleConn(ctx context.Context, _ stats.ConnStats) {}
+
+func payloadBytesFields(in, out int64) map[string]string {
+	return map[string]string{
+		fieldRequestPayloadBytes:  formatInt64(in),
+		fieldResponsePayloadBytes: formatInt64(out),
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2016 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+)
+
+// ServerMetrics represents a collection of Prometheus metrics that must be registered on a Prometheus registry for
+// a gRPC server.
+type ServerMetrics struct {
+	serverStartedCounter    *prometheus.CounterVec
+	serverHandledCounter    *prometheus.CounterVec
+	serverStreamMsgReceived *prometheus.CounterVec
+	serverStreamMsgSent     *prometheus.CounterVec
+
+	serverHandledHistogramEnabled bool
+	serverHandledHistogramOpts    prometheus.HistogramOpts
+	serverHandledHistogram        *prometheus.HistogramVec
+
+	payloadSizeHistogramEnabled     bool
+	msgReceivedBytesHistogramOpts   prometheus.HistogramOpts
+	msgSentBytesHistogramOpts       prometheus.HistogramOpts
+	serverMsgReceivedBytesHistogram *prometheus.HistogramVec
+	serverMsgSentBytesHistogram     *prometheus.HistogramVec
+
+	reg             prometheus.Registerer
+	exemplarFromCtx ExemplarFromContext
+}
+
+// NewServerMetrics returns a ServerMetrics object, pre-registered with the given Prometheus registerer.
+func NewServerMetrics(reg prometheus.Registerer, opts ...Option) *ServerMetrics {
+	o := evaluateOpts(opts)
+	if o.subsystem != "" {
+		o.constLabels["grpc_server_type"] = o.subsystem
+	}
+	m := &ServerMetrics{
+		reg:             reg,
+		exemplarFromCtx: o.exemplarFromCtx,
+		serverStartedCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "grpc_server_started_total",
+			Help:        "Total number of RPCs started on the server.",
+			ConstLabels: o.constLabels,
+		}, []string{"grpc_type", "grpc_service", "grpc_method"}),
+		serverHandledCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "grpc_server_handled_total",
+			Help:        "Total number of RPCs completed on the server, regardless of success or failure.",
+			ConstLabels: o.constLabels,
+		}, []string{"grpc_type", "grpc_service", "grpc_method", "grpc_code"}),
+		serverStreamMsgReceived: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "grpc_server_msg_received_total",
+			Help:        "Total number of RPC stream messages received by the server.",
+			ConstLabels: o.constLabels,
+		}, []string{"grpc_type", "grpc_service", "grpc_method"}),
+		serverStreamMsgSent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "grpc_server_msg_sent_total",
+			Help:        "Total number of gRPC stream messages sent by the server.",
+			ConstLabels: o.constLabels,
+		}, []string{"grpc_type", "grpc_service", "grpc_method"}),
+		serverHandledHistogramOpts: prometheus.HistogramOpts{
+			Name:        "grpc_server_handling_seconds",
+			Help:        "Histogram of response latency (seconds) of gRPC that had been application-level handled by the server.",
+			Buckets:     prometheus.DefBuckets,
+			ConstLabels: o.constLabels,
+		},
+		msgReceivedBytesHistogramOpts: prometheus.HistogramOpts{
+			Name:        "grpc_server_msg_received_bytes",
+			Help:        "Histogram of the total wire size, in bytes, of all messages received by the server in one RPC.",
+			Buckets:     prometheus.ExponentialBuckets(64, 4, 10),
+			ConstLabels: o.constLabels,
+		},
+		msgSentBytesHistogramOpts: prometheus.HistogramOpts{
+			Name:        "grpc_server_msg_sent_bytes",
+			Help:        "Histogram of the total wire size, in bytes, of all messages sent by the server in one RPC.",
+			Buckets:     prometheus.ExponentialBuckets(64, 4, 10),
+			ConstLabels: o.constLabels,
+		},
+	}
+
+	m.serverStartedCounter = registerOrReuse(reg, m.serverStartedCounter).(*prometheus.CounterVec)
+	m.serverHandledCounter = registerOrReuse(reg, m.serverHandledCounter).(*prometheus.CounterVec)
+	m.serverStreamMsgReceived = registerOrReuse(reg, m.serverStreamMsgReceived).(*prometheus.CounterVec)
+	m.serverStreamMsgSent = registerOrReuse(reg, m.serverStreamMsgSent).(*prometheus.CounterVec)
+	return m
+}
+
+// EnableServerHandlingTimeHistogram turns on recording of handling time of RPCs. Histogram metrics can be very
+// expensive for Prometheus to retain and query, so it is disabled by default.
+func (m *ServerMetrics) EnableServerHandlingTimeHistogram(opts ...HistogramOption) {
+	for _, o := range opts {
+		o(&m.serverHandledHistogramOpts)
+	}
+	if !m.serverHandledHistogramEnabled {
+		m.serverHandledHistogram = registerOrReuse(m.reg, prometheus.NewHistogramVec(
+			m.serverHandledHistogramOpts,
+			[]string{"grpc_type", "grpc_service", "grpc_method"},
+		)).(*prometheus.HistogramVec)
+	}
+	m.serverHandledHistogramEnabled = true
+}
+
+// EnablePayloadSizeHistogram turns on recording of the wire size of inbound and outbound message payloads, as
+// observed by a ServerPayloadStatsHandler registered on the same grpc.Server: one sample per RPC and direction,
+// holding the summed size of all its messages. Like the handling-time histogram, this is
+// disabled by default because histograms are expensive for Prometheus to retain and query.
+func (m *ServerMetrics) EnablePayloadSizeHistogram(opts ...HistogramOption) {
+	for _, o := range opts {
+		o(&m.msgReceivedBytesHistogramOpts)
+		o(&m.msgSentBytesHistogramOpts)
+	}
+	if !m.payloadSizeHistogramEnabled {
+		m.serverMsgReceivedBytesHistogram = registerOrReuse(m.reg, prometheus.NewHistogramVec(
+			m.msgReceivedBytesHistogramOpts,
+			[]string{"grpc_type", "grpc_service", "grpc_method"},
+		)).(*prometheus.HistogramVec)
+		m.serverMsgSentBytesHistogram = registerOrReuse(m.reg, prometheus.NewHistogramVec(
+			m.msgSentBytesHistogramOpts,
+			[]string{"grpc_type", "grpc_service", "grpc_method"},
+		)).(*prometheus.HistogramVec)
+	}
+	m.payloadSizeHistogramEnabled = true
+}
+
+// UnaryServerInterceptor is a grpc.UnaryServerInterceptor that provides Prometheus monitoring for unary RPCs.
+func (m *ServerMetrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		service, method := splitMethodName(info.FullMethod)
+		tagServerRPCType(ctx, typeUnary)
+		incCounter(ctx, m.exemplarFromCtx, m.serverStartedCounter.WithLabelValues(typeUnary, service, method))
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.reportHandled(ctx, typeUnary, service, method, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor is a grpc.StreamServerInterceptor that provides Prometheus monitoring for streaming RPCs.
+func (m *ServerMetrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		service, method := splitMethodName(info.FullMethod)
+		rpcType := serverStreamType(info)
+		tagServerRPCType(ss.Context(), rpcType)
+		incCounter(ss.Context(), m.exemplarFromCtx, m.serverStartedCounter.WithLabelValues(rpcType, service, method))
+
+		start := time.Now()
+		err := handler(srv, &monitoredServerStream{ServerStream: ss, metrics: m, rpcType: rpcType, service: service, method: method})
+		m.reportHandled(ss.Context(), rpcType, service, method, start, err)
+		return err
+	}
+}
+
+func (m *ServerMetrics) reportHandled(ctx context.Context, rpcType, service, method string, start time.Time, err error) {
+	st, _ := statusFromError(err)
+	incCounter(ctx, m.exemplarFromCtx, m.serverHandledCounter.WithLabelValues(rpcType, service, method, st.Code().String()))
+	if m.serverHandledHistogramEnabled {
+		observeHistogram(ctx, m.exemplarFromCtx, m.serverHandledHistogram.WithLabelValues(rpcType, service, method), time.Since(start).Seconds())
+	}
+}
+
+type monitoredServerStream struct {
+	grpc.ServerStream
+	metrics *ServerMetrics
+	rpcType string
+	service string
+	method  string
+}
+
+func (s *monitoredServerStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.metrics.serverStreamMsgSent.WithLabelValues(s.rpcType, s.service, s.method).Inc()
+	}
+	return err
+}
+
+func (s *monitoredServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.metrics.serverStreamMsgReceived.WithLabelValues(s.rpcType, s.service, s.method).Inc()
+	}
+	return err
+}
+
+func serverStreamType(info *grpc.StreamServerInfo) string {
+	if info.IsClientStream && info.IsServerStream {
+		return typeBidiStream
+	} else if info.IsClientStream {
+		return typeClientStream
+	}
+	return typeServerStream
+}
+
+// UnaryServerInterceptor is a convenience function that builds a ServerMetrics registered with reg and returns its
+// UnaryServerInterceptor.
+func UnaryServerInterceptor(reg prometheus.Registerer, opts ...Option) grpc.UnaryServerInterceptor {
+	return NewServerMetrics(reg, opts...).UnaryServerInterceptor()
+}
+
+// StreamServerInterceptor is a convenience function that builds a ServerMetrics registered with reg and returns its
+// StreamServerInterceptor.
+func StreamServerInterceptor(reg prometheus.Registerer, opts ...Option) grpc.StreamServerInterceptor {
+	return NewServerMetrics(reg, opts...).StreamServerInterceptor()
+}
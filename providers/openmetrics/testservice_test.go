@@ -0,0 +1,64 @@
+// Copyright 2016 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	pb_testproto "github.com/grpc-ecosystem/go-grpc-middleware/providers/openmetrics/v2/testproto/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// countListResponses is the number of messages testService.PingList streams back for a successful call.
+const countListResponses = 20
+
+// testService is a bare-bones pb_testproto.TestServiceServer used to drive ClientMetrics (and, via
+// PayloadStatsHandler, wire-size observations) over a real gRPC connection.
+type testService struct {
+	t *testing.T
+}
+
+func (s *testService) PingEmpty(ctx context.Context, _ *pb_testproto.PingEmptyRequest) (*pb_testproto.PingEmptyResponse, error) {
+	return &pb_testproto.PingEmptyResponse{Value: "pong"}, nil
+}
+
+func (s *testService) PingError(ctx context.Context, ping *pb_testproto.PingErrorRequest) (*pb_testproto.Empty, error) {
+	code := codes.Code(ping.ErrorCodeReturned)
+	if code == codes.OK {
+		return &pb_testproto.Empty{}, nil
+	}
+	return nil, status.Error(code, "Userspace error.")
+}
+
+func (s *testService) PingList(ping *pb_testproto.PingListRequest, stream pb_testproto.TestService_PingListServer) error {
+	if ping.ErrorCodeReturned != 0 {
+		return status.Error(codes.Code(ping.ErrorCodeReturned), "Userspace error.")
+	}
+	for i := 0; i < countListResponses; i++ {
+		if err := stream.Send(&pb_testproto.PingListResponse{Value: fmt.Sprintf("msg_%d", i), Counter: int32(i)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// requireValue asserts that c currently reports expected, e.g. for a *prometheus.CounterVec.WithLabelValues result.
+func requireValue(t *testing.T, expected int, c prometheus.Counter) {
+	t.Helper()
+	var m dto.Metric
+	require.NoError(t, c.(prometheus.Metric).Write(&m))
+	require.EqualValues(t, expected, m.GetCounter().GetValue())
+}
+
+// requireValueHistCount asserts that the histogram observer o has recorded expected samples so far.
+func requireValueHistCount(t *testing.T, expected int, o prometheus.Observer) {
+	t.Helper()
+	require.EqualValues(t, expected, histCount(o))
+}
@@ -0,0 +1,52 @@
+// Copyright 2016 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package metrics
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/status"
+)
+
+// formatInt64 is a small strconv.FormatInt wrapper kept local so callers don't need to import strconv just to
+// stringify a byte count for a logging.Fields value.
+func formatInt64(v int64) string {
+	return strconv.FormatInt(v, 10)
+}
+
+type rpcTypeCtxMarker struct{}
+
+var rpcTypeCtxKey = &rpcTypeCtxMarker{}
+
+// withRPCType stashes the gRPC call type ("unary", "client_stream", ...) on ctx so that a stats.Handler installed
+// alongside these interceptors (which isn't otherwise told whether a call is streaming) can label its own metrics
+// consistently with the interceptor-based ones.
+func withRPCType(ctx context.Context, rpcType string) context.Context {
+	return context.WithValue(ctx, rpcTypeCtxKey, rpcType)
+}
+
+// rpcTypeFromContext returns the call type stashed by withRPCType, defaulting to "unary" if none was set.
+func rpcTypeFromContext(ctx context.Context) string {
+	if t, ok := ctx.Value(rpcTypeCtxKey).(string); ok {
+		return t
+	}
+	return typeUnary
+}
+
+// splitMethodName splits a gRPC full method name ("/package.Service/Method") into its service and method parts.
+func splitMethodName(fullMethod string) (service string, method string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/") // remove leading slash
+	if i := strings.Index(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", "unknown"
+}
+
+// statusFromError mirrors status.FromError. For a nil error it returns a nil *status.Status, whose Code() is
+// codes.OK, so callers can call Code() on the result without a nil check.
+func statusFromError(err error) (*status.Status, bool) {
+	return status.FromError(err)
+}
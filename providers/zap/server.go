@@ -0,0 +1,20 @@
+// Copyright 2017 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package zap
+
+import (
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// UnaryServerInterceptor returns a new unary server interceptor that logs the final line of each call via logger.
+func UnaryServerInterceptor(logger *zap.Logger, opts ...logging.Option) grpc.UnaryServerInterceptor {
+	return logging.UnaryServerInterceptor(InterceptorLogger(logger), opts...)
+}
+
+// StreamServerInterceptor returns a new streaming server interceptor that logs the final line of each call via logger.
+func StreamServerInterceptor(logger *zap.Logger, opts ...logging.Option) grpc.StreamServerInterceptor {
+	return logging.StreamServerInterceptor(InterceptorLogger(logger), opts...)
+}
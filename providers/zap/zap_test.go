@@ -0,0 +1,66 @@
+// Copyright 2017 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package zap_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	grpc_zap "github.com/grpc-ecosystem/go-grpc-middleware/v2/providers/zap"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+)
+
+func TestInterceptorLogger(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		level     logging.Level
+		code      string
+		wantLevel zapcore.Level
+	}{
+		{name: "ok call logs info", level: logging.LevelInfo, code: "OK", wantLevel: zapcore.InfoLevel},
+		{name: "erroring call logs error", level: logging.LevelError, code: "Internal", wantLevel: zapcore.ErrorLevel},
+		{name: "cancelled call logs warn", level: logging.LevelWarn, code: "Canceled", wantLevel: zapcore.WarnLevel},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+			base := zap.New(core)
+			logger := grpc_zap.InterceptorLogger(base)
+
+			logger.Log(context.Background(), tt.level, "finished unary call", "grpc.service", "mwitkow.testproto.TestService", "grpc.code", tt.code)
+
+			require.Equal(t, 1, logs.Len())
+			entry := logs.All()[0]
+			require.Equal(t, tt.wantLevel, entry.Level)
+			require.Equal(t, "finished unary call", entry.Message)
+			require.Equal(t, "mwitkow.testproto.TestService", entry.ContextMap()["grpc.service"])
+		})
+	}
+}
+
+func TestPayloadUnaryServerInterceptor_SamplerGatesLogging(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	base := zap.New(core)
+
+	alwaysDecide := func(ctx context.Context, fullMethodName string, servingObject interface{}) bool { return true }
+	// No draw in [0, 1) is below a 0 success fraction, so ErrorAndSampleDecider(0) never samples a successful call;
+	// the fixed source only makes the draws reproducible.
+	sampler := logging.ErrorAndSampleDecider(0, rand.NewSource(1))
+
+	interceptor := grpc_zap.PayloadUnaryServerInterceptor(base, logging.LevelDebug, alwaysDecide, sampler)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "resp", nil }
+	info := &grpc.UnaryServerInfo{FullMethod: "/mwitkow.testproto.TestService/PingEmpty"}
+
+	_, err := interceptor(context.Background(), "req", info, handler)
+	require.NoError(t, err)
+
+	for _, e := range logs.All() {
+		require.NotEqual(t, "payload logged", e.Message, "a 0%% sample fraction must never log a successful call's payload")
+	}
+}
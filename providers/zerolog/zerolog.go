@@ -0,0 +1,42 @@
+// Copyright 2017 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+// Package zerolog adapts a zerolog.Logger to the `logging.Logger` interface defined in the parent `logging`
+// package, so it can be used with the generic interceptors/logging unary and streaming interceptors.
+package zerolog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"github.com/rs/zerolog"
+)
+
+// InterceptorLogger adapts a zerolog.Logger to interceptor logging.Logger.
+func InterceptorLogger(l zerolog.Logger) logging.Logger {
+	return logging.LoggerFunc(func(ctx context.Context, lvl logging.Level, msg string, fields ...any) {
+		var e *zerolog.Event
+		switch lvl {
+		case logging.LevelDebug:
+			e = l.Debug()
+		case logging.LevelInfo:
+			e = l.Info()
+		case logging.LevelWarn:
+			e = l.Warn()
+		case logging.LevelError:
+			e = l.Error()
+		default:
+			panic(fmt.Sprintf("unknown level %v", lvl))
+		}
+
+		for i := 0; i+1 < len(fields); i += 2 {
+			key, ok := fields[i].(string)
+			if !ok {
+				continue
+			}
+			e = e.Interface(key, fields[i+1])
+		}
+		e.Msg(msg)
+	})
+}
@@ -0,0 +1,64 @@
+// Copyright 2017 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package zerolog_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	grpc_zerolog "github.com/grpc-ecosystem/go-grpc-middleware/v2/providers/zerolog"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestInterceptorLogger(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		level     logging.Level
+		code      string
+		wantLevel string
+	}{
+		{name: "ok call logs info", level: logging.LevelInfo, code: "OK", wantLevel: "info"},
+		{name: "erroring call logs error", level: logging.LevelError, code: "Internal", wantLevel: "error"},
+		{name: "cancelled call logs warn", level: logging.LevelWarn, code: "Canceled", wantLevel: "warn"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			base := zerolog.New(&buf)
+			logger := grpc_zerolog.InterceptorLogger(base)
+
+			logger.Log(context.Background(), tt.level, "finished unary call", "grpc.service", "mwitkow.testproto.TestService", "grpc.code", tt.code)
+
+			var got map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+			require.Equal(t, tt.wantLevel, got["level"])
+			require.Equal(t, "finished unary call", got["message"])
+			require.Equal(t, "mwitkow.testproto.TestService", got["grpc.service"])
+		})
+	}
+}
+
+func TestPayloadUnaryServerInterceptor_SamplerGatesLogging(t *testing.T) {
+	var buf bytes.Buffer
+	base := zerolog.New(&buf)
+
+	alwaysDecide := func(ctx context.Context, fullMethodName string, servingObject interface{}) bool { return true }
+	// No draw in [0, 1) is below a 0 success fraction, so ErrorAndSampleDecider(0) never samples a successful call;
+	// the fixed source only makes the draws reproducible.
+	sampler := logging.ErrorAndSampleDecider(0, rand.NewSource(1))
+
+	interceptor := grpc_zerolog.PayloadUnaryServerInterceptor(base, logging.LevelDebug, alwaysDecide, sampler)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "resp", nil }
+	info := &grpc.UnaryServerInfo{FullMethod: "/mwitkow.testproto.TestService/PingEmpty"}
+
+	_, err := interceptor(context.Background(), "req", info, handler)
+	require.NoError(t, err)
+
+	require.NotContains(t, buf.String(), "payload logged", "a 0%% sample fraction must never log a successful call's payload")
+}
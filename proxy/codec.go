@@ -0,0 +1,42 @@
+// Copyright 2017 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package proxy
+
+import "google.golang.org/grpc/encoding"
+
+// NewCodec returns a raw-bytes, passthrough grpc/encoding.Codec keyed as "proto", the same name as the standard
+// protobuf codec. It is meant to be installed on a single proxying `*grpc.Server` via `grpc.ForceServerCodec`, and
+// on the outgoing call to the backend via `grpc.ForceCodec` — both scoped to that server/call only, so it never
+// touches the process-wide codec registry and coexists fine with ordinary proto-decoding services elsewhere in
+// the same process.
+//
+// When asked to (un)marshal a *Frame, it copies the wire bytes verbatim. For any other type it falls back to the
+// standard protobuf codec, so a server built with it can still serve regular, fully-decoded RPCs alongside
+// proxied ones (see RegisterService).
+func NewCodec() encoding.Codec {
+	return &rawCodec{fallback: encoding.GetCodec("proto")}
+}
+
+type rawCodec struct {
+	fallback encoding.Codec
+}
+
+func (c *rawCodec) Marshal(v interface{}) ([]byte, error) {
+	if f, ok := v.(*Frame); ok {
+		return f.payload, nil
+	}
+	return c.fallback.Marshal(v)
+}
+
+func (c *rawCodec) Unmarshal(data []byte, v interface{}) error {
+	if f, ok := v.(*Frame); ok {
+		f.payload = append(f.payload[:0], data...)
+		return nil
+	}
+	return c.fallback.Unmarshal(data, v)
+}
+
+func (c *rawCodec) Name() string {
+	return "proto"
+}
@@ -0,0 +1,18 @@
+// Copyright 2017 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package proxy
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// StreamDirector decides which backend a given proxied call should be forwarded to, and returns the (possibly
+// amended, e.g. with outgoing metadata attached via metadata.NewOutgoingContext) context to dial it with.
+//
+// fullMethodName has the form "/package.Service/Method". StreamDirector is called once per incoming RPC, after the
+// first frame has already been read off it - use PeekMessage to inspect it (e.g. to pick a backend by payload
+// content) before it is forwarded.
+type StreamDirector func(ctx context.Context, fullMethodName string) (context.Context, *grpc.ClientConn, error)
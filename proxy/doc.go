@@ -0,0 +1,26 @@
+// Copyright 2017 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+/*
+Package proxy implements a transparent gRPC reverse proxy.
+
+It lets a gRPC server forward requests for methods it does not itself implement to an arbitrary backend
+`*grpc.ClientConn`, chosen per-call by a user-supplied StreamDirector, without ever unmarshalling the request or
+response into a concrete proto.Message. This is achieved by registering a Codec that passes the wire bytes straight
+through as a Frame, and installing TransparentHandler as the server's grpc.UnknownServiceHandler.
+
+Typical usage:
+
+	director := func(ctx context.Context, fullMethodName string) (context.Context, *grpc.ClientConn, error) {
+		// Incoming metadata is already copied onto ctx's outgoing side by the handler; add routing logic here.
+		return ctx, backendConn, nil
+	}
+	server := grpc.NewServer(
+		grpc.ForceServerCodec(proxy.NewCodec()),
+		grpc.UnknownServiceHandler(proxy.TransparentHandler(director)),
+	)
+
+RegisterService can be used instead of (or alongside) UnknownServiceHandler to proxy only a known subset of
+methods, leaving the rest to be served locally by regular, fully-decoded handlers registered on the same server.
+*/
+package proxy
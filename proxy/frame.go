@@ -0,0 +1,17 @@
+// Copyright 2017 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package proxy
+
+// Frame is a raw, unparsed gRPC message. Codec marshals and unmarshals it as a straight byte-for-byte copy of the
+// wire payload, which is what lets TransparentHandler forward messages without knowing their proto type.
+type Frame struct {
+	payload []byte
+}
+
+// Reset implements proto.Message-shaped reset, so a Frame can be passed to generic code that expects it.
+func (f *Frame) Reset() { f.payload = nil }
+
+func (f *Frame) String() string {
+	return "proxy.Frame"
+}
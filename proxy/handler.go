@@ -0,0 +1,177 @@
+// Copyright 2017 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package proxy
+
+import (
+	"context"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var clientStreamDescForProxying = &grpc.StreamDesc{
+	ServerStreams: true,
+	ClientStreams: true,
+}
+
+type peekedFrameCtxMarker struct{}
+
+var peekedFrameCtxKey = &peekedFrameCtxMarker{}
+
+// PeekMessage returns the raw bytes of the first message of the RPC currently being routed, as seen by a
+// StreamDirector. TransparentHandler and the handler installed by RegisterService always read the first frame
+// before invoking the director, so a director can inspect it (e.g. to extract a tenant id) to pick a backend.
+func PeekMessage(ctx context.Context) ([]byte, bool) {
+	f, ok := ctx.Value(peekedFrameCtxKey).(*Frame)
+	if !ok {
+		return nil, false
+	}
+	return f.payload, true
+}
+
+// TransparentHandler returns a grpc.StreamHandler that forwards any call it receives to the backend chosen by
+// director, via a raw (*Frame)-based passthrough. It is intended to be installed via
+// grpc.UnknownServiceHandler(proxy.TransparentHandler(director)).
+func TransparentHandler(director StreamDirector) grpc.StreamHandler {
+	h := &handler{director: director}
+	return h.handle
+}
+
+// RegisterService registers a fake grpc.ServiceDesc on server for serviceName/methodNames, so that those specific
+// methods are proxied via director, while any other service registered on the same server is served normally.
+func RegisterService(server *grpc.Server, director StreamDirector, serviceName string, methodNames ...string) {
+	h := &handler{director: director}
+	fakeDesc := &grpc.ServiceDesc{
+		ServiceName: serviceName,
+		HandlerType: (*interface{})(nil),
+	}
+	for _, name := range methodNames {
+		fakeDesc.Streams = append(fakeDesc.Streams, grpc.StreamDesc{
+			StreamName:    name,
+			Handler:       h.handle,
+			ServerStreams: true,
+			ClientStreams: true,
+		})
+	}
+	server.RegisterService(fakeDesc, h)
+}
+
+type handler struct {
+	director StreamDirector
+}
+
+func (s *handler) handle(srv interface{}, serverStream grpc.ServerStream) error {
+	fullMethodName, ok := grpc.MethodFromServerStream(serverStream)
+	if !ok {
+		return status.Error(codes.Internal, "proxy: lowLevelServerStream not exists in context")
+	}
+
+	peeked := &Frame{}
+	if err := serverStream.RecvMsg(peeked); err != nil {
+		return err
+	}
+
+	ctx := incomingToOutgoing(context.WithValue(serverStream.Context(), peekedFrameCtxKey, peeked))
+	outgoingCtx, backendConn, err := s.director(ctx, fullMethodName)
+	if err != nil {
+		return err
+	}
+
+	clientCtx, clientCancel := context.WithCancel(outgoingCtx)
+	defer clientCancel()
+
+	clientStream, err := grpc.NewClientStream(clientCtx, clientStreamDescForProxying, backendConn, fullMethodName, grpc.ForceCodec(NewCodec()))
+	if err != nil {
+		return err
+	}
+	if err := clientStream.SendMsg(peeked); err != nil {
+		return err
+	}
+
+	s2cErrChan := forwardServerToClient(serverStream, clientStream)
+	c2sErrChan := forwardClientToServer(clientStream, serverStream)
+	for i := 0; i < 2; i++ {
+		select {
+		case s2cErr := <-s2cErrChan:
+			if s2cErr == io.EOF {
+				// Client finished sending; half-close the backend call and keep reading its responses.
+				clientStream.CloseSend()
+				continue
+			}
+			clientCancel()
+			return status.Errorf(codes.Internal, "proxy: error forwarding client->backend: %v", s2cErr)
+		case c2sErr := <-c2sErrChan:
+			serverStream.SetTrailer(clientStream.Trailer())
+			if c2sErr != io.EOF {
+				return c2sErr
+			}
+			return nil
+		}
+	}
+	return status.Error(codes.Internal, "proxy: gRPC proxying should never reach this stage")
+}
+
+// forwardClientToServer reads messages from the backend (src) and writes them to the caller (dst), forwarding
+// response headers on the first message.
+func forwardClientToServer(src grpc.ClientStream, dst grpc.ServerStream) chan error {
+	ret := make(chan error, 1)
+	go func() {
+		f := &Frame{}
+		for i := 0; ; i++ {
+			if err := src.RecvMsg(f); err != nil {
+				ret <- err
+				return
+			}
+			if i == 0 {
+				md, err := src.Header()
+				if err != nil {
+					ret <- err
+					return
+				}
+				if err := dst.SendHeader(md); err != nil {
+					ret <- err
+					return
+				}
+			}
+			if err := dst.SendMsg(f); err != nil {
+				ret <- err
+				return
+			}
+		}
+	}()
+	return ret
+}
+
+// forwardServerToClient reads messages from the caller (src) and writes them to the backend (dst). It does not touch
+// metadata: handle copies the incoming metadata onto the backend call's context before the stream is opened.
+func forwardServerToClient(src grpc.ServerStream, dst grpc.ClientStream) chan error {
+	ret := make(chan error, 1)
+	go func() {
+		f := &Frame{}
+		for {
+			if err := src.RecvMsg(f); err != nil {
+				ret <- err
+				return
+			}
+			if err := dst.SendMsg(f); err != nil {
+				ret <- err
+				return
+			}
+		}
+	}()
+	return ret
+}
+
+// incomingToOutgoing copies the incoming request metadata onto ctx's outgoing side. handle applies it to the context
+// it passes to the StreamDirector, which is what makes auth/tracing headers survive the hop.
+func incomingToOutgoing(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return metadata.NewOutgoingContext(ctx, md.Copy())
+}
@@ -0,0 +1,216 @@
+// Copyright 2017 Michal Witkowski. All Rights Reserved.
+// See LICENSE for licensing terms.
+
+package proxy_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/proxy"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/proxy/testservice"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const pingListCount = 5
+
+// pingBackend is the "real" service being proxied to. It never talks to the proxy.
+type pingBackend struct{}
+
+func (s *pingBackend) Ping(ctx context.Context, in *testservice.PingRequest) (*testservice.PingResponse, error) {
+	return &testservice.PingResponse{Value: in.Value, Counter: 1}, nil
+}
+
+func (s *pingBackend) PingError(ctx context.Context, in *testservice.PingRequest) (*testservice.Empty, error) {
+	return nil, status.Errorf(codes.Code(in.ErrorCodeReturned), "error as requested")
+}
+
+func (s *pingBackend) PingList(in *testservice.PingRequest, stream testservice.PingService_PingListServer) error {
+	for i := 0; i < pingListCount; i++ {
+		if err := stream.Send(&testservice.PingResponse{Value: in.Value, Counter: int32(i)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *pingBackend) PingStream(stream testservice.PingService_PingStreamServer) error {
+	i := int32(0)
+	for {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(&testservice.PingResponse{Value: in.Value, Counter: i}); err != nil {
+			return err
+		}
+		i++
+	}
+}
+
+func TestProxyHandlerSuite(t *testing.T) {
+	suite.Run(t, &ProxyHandlerTestSuite{})
+}
+
+// ProxyHandlerTestSuite wires up a backend grpc.Server, a proxying grpc.Server whose StreamDirector always forwards
+// to the backend, and a client dialed against the proxy, then exercises all four call shapes through it.
+type ProxyHandlerTestSuite struct {
+	suite.Suite
+
+	backendListener net.Listener
+	backendServer   *grpc.Server
+	backendConn     *grpc.ClientConn
+
+	proxyListener net.Listener
+	proxyServer   *grpc.Server
+	proxyConn     *grpc.ClientConn
+
+	client testservice.PingServiceClient
+}
+
+func (s *ProxyHandlerTestSuite) SetupSuite() {
+	var err error
+
+	s.backendListener, err = net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(s.T(), err)
+	s.backendServer = grpc.NewServer()
+	testservice.RegisterPingServiceServer(s.backendServer, &pingBackend{})
+	go func() { s.backendServer.Serve(s.backendListener) }()
+
+	s.backendConn, err = grpc.Dial(s.backendListener.Addr().String(), grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(s.T(), err)
+
+	director := func(ctx context.Context, fullMethodName string) (context.Context, *grpc.ClientConn, error) {
+		return ctx, s.backendConn, nil
+	}
+	s.proxyListener, err = net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(s.T(), err)
+	s.proxyServer = grpc.NewServer(
+		grpc.ForceServerCodec(proxy.NewCodec()),
+		grpc.UnknownServiceHandler(proxy.TransparentHandler(director)),
+	)
+	go func() { s.proxyServer.Serve(s.proxyListener) }()
+
+	s.proxyConn, err = grpc.Dial(s.proxyListener.Addr().String(), grpc.WithInsecure(), grpc.WithBlock())
+	require.NoError(s.T(), err)
+	s.client = testservice.NewPingServiceClient(s.proxyConn)
+}
+
+func (s *ProxyHandlerTestSuite) TearDownSuite() {
+	if s.proxyConn != nil {
+		s.proxyConn.Close()
+	}
+	if s.proxyServer != nil {
+		s.proxyServer.Stop()
+		s.proxyListener.Close()
+	}
+	if s.backendConn != nil {
+		s.backendConn.Close()
+	}
+	if s.backendServer != nil {
+		s.backendServer.Stop()
+		s.backendListener.Close()
+	}
+}
+
+func (s *ProxyHandlerTestSuite) TestUnary_Proxies() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	resp, err := s.client.Ping(ctx, &testservice.PingRequest{Value: "hello"})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), "hello", resp.Value)
+}
+
+func (s *ProxyHandlerTestSuite) TestUnary_PropagatesBackendError() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := s.client.PingError(ctx, &testservice.PingRequest{ErrorCodeReturned: uint32(codes.FailedPrecondition)})
+	st, ok := status.FromError(err)
+	require.True(s.T(), ok)
+	require.Equal(s.T(), codes.FailedPrecondition, st.Code())
+}
+
+func (s *ProxyHandlerTestSuite) TestServerStream_Proxies() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	stream, err := s.client.PingList(ctx, &testservice.PingRequest{Value: "list"})
+	require.NoError(s.T(), err)
+	count := 0
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(s.T(), err)
+		require.Equal(s.T(), "list", resp.Value)
+		count++
+	}
+	require.Equal(s.T(), pingListCount, count)
+}
+
+// TestClientStream_Proxies drives the bidi PingStream in the client-streaming shape (send everything, half-close,
+// then read), since PingService has no client-streaming-only method.
+func (s *ProxyHandlerTestSuite) TestClientStream_Proxies() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	stream, err := s.client.PingStream(ctx)
+	require.NoError(s.T(), err)
+	for i := 0; i < 3; i++ {
+		require.NoError(s.T(), stream.Send(&testservice.PingRequest{Value: "cs"}))
+	}
+	require.NoError(s.T(), stream.CloseSend())
+	count := 0
+	for {
+		_, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(s.T(), err)
+		count++
+	}
+	require.Equal(s.T(), 3, count)
+}
+
+func (s *ProxyHandlerTestSuite) TestBidiStream_Proxies() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	stream, err := s.client.PingStream(ctx)
+	require.NoError(s.T(), err)
+	for i := 0; i < 3; i++ {
+		require.NoError(s.T(), stream.Send(&testservice.PingRequest{Value: "bidi"}))
+		resp, err := stream.Recv()
+		require.NoError(s.T(), err)
+		require.EqualValues(s.T(), i, resp.Counter)
+	}
+	require.NoError(s.T(), stream.CloseSend())
+	_, err = stream.Recv()
+	require.Equal(s.T(), io.EOF, err)
+}
+
+func (s *ProxyHandlerTestSuite) TestCancellation_DoesNotLeakGoroutines() {
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := s.client.PingStream(ctx)
+	require.NoError(s.T(), err)
+	require.NoError(s.T(), stream.Send(&testservice.PingRequest{Value: "cancel-me"}))
+	_, err = stream.Recv()
+	require.NoError(s.T(), err)
+
+	cancel()
+
+	require.Eventually(s.T(), func() bool {
+		return runtime.NumGoroutine() <= before+1
+	}, 2*time.Second, 10*time.Millisecond, "forwarding goroutines spawned by the proxy handler must exit after the caller cancels")
+}